@@ -0,0 +1,23 @@
+// Command config-schema writes the JSON Schema for the transformation
+// config format to stdout, so editors like VS Code's YAML extension or
+// IntelliJ can offer completion for asset type names and catch authoring
+// typos - e.g. "FQDN-IPAddress" missing the "->" - before they reach
+// production.
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/cupello/config"
+)
+
+func main() {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(config.Schema()); err != nil {
+		fmt.Fprintln(os.Stderr, "config-schema:", err)
+		os.Exit(1)
+	}
+}
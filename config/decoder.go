@@ -0,0 +1,163 @@
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigDecoder decodes raw config bytes into conf. LoadConfig picks an
+// implementation by file extension; RegisterDecoder lets downstream
+// modules add formats (HCL, a house format, ...) without forking this
+// package.
+type ConfigDecoder interface {
+	Decode(data []byte, conf *Config) error
+}
+
+// DecoderFunc adapts a plain function to a ConfigDecoder.
+type DecoderFunc func(data []byte, conf *Config) error
+
+// Decode calls f.
+func (f DecoderFunc) Decode(data []byte, conf *Config) error { return f(data, conf) }
+
+var (
+	decodersMu sync.RWMutex
+	decoders   = map[string]ConfigDecoder{
+		".yaml": DecoderFunc(func(data []byte, conf *Config) error { return yaml.Unmarshal(data, conf) }),
+		".yml":  DecoderFunc(func(data []byte, conf *Config) error { return yaml.Unmarshal(data, conf) }),
+		".json": DecoderFunc(func(data []byte, conf *Config) error { return json.Unmarshal(data, conf) }),
+		".toml": DecoderFunc(func(data []byte, conf *Config) error { return toml.Unmarshal(data, conf) }),
+		".env":  DecoderFunc(decodeDotenv),
+	}
+)
+
+// RegisterDecoder registers d to decode files whose extension, including
+// the leading dot (e.g. ".hcl"), matches ext, replacing any decoder
+// already registered for it.
+func RegisterDecoder(ext string, d ConfigDecoder) {
+	decodersMu.Lock()
+	defer decodersMu.Unlock()
+	decoders[strings.ToLower(ext)] = d
+}
+
+func decoderFor(ext string) (ConfigDecoder, bool) {
+	decodersMu.RLock()
+	defer decodersMu.RUnlock()
+	d, ok := decoders[strings.ToLower(ext)]
+	return d, ok
+}
+
+// decodeDotenv parses flat KEY=VALUE lines, e.g.
+//
+//	TRANSFORM_FQDN_IPADDRESS_PRIORITY=1
+//	TRANSFORM_FQDN_IPADDRESS_CONFIDENCE=80
+//
+// onto conf's nested Transformations map, and OPTIONS_CONFIDENCE onto
+// conf.BaseOptions.Confidence.
+func decodeDotenv(data []byte, conf *Config) error {
+	if conf.Transformations == nil {
+		conf.Transformations = make(map[string]*Transformation)
+	}
+
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		kv := strings.SplitN(line, "=", 2)
+		if len(kv) != 2 {
+			return fmt.Errorf("dotenv: malformed line %q", line)
+		}
+		key := strings.TrimSpace(kv[0])
+		val := strings.Trim(strings.TrimSpace(kv[1]), `"'`)
+
+		switch {
+		case key == "OPTIONS_CONFIDENCE":
+			n, err := strconv.Atoi(val)
+			if err != nil {
+				return fmt.Errorf("dotenv: %s: %w", key, err)
+			}
+			conf.BaseOptions.Confidence = n
+		case strings.HasPrefix(key, "TRANSFORM_"):
+			if err := setDotenvTransformField(conf, key, val); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("dotenv: unrecognized key %q", key)
+		}
+	}
+	return sc.Err()
+}
+
+// setDotenvTransformField applies a single TRANSFORM_<FROM>_<TO>_<FIELD>
+// key to the "From->To" entry of conf.Transformations, creating it if
+// necessary.
+func setDotenvTransformField(conf *Config, key, val string) error {
+	parts := strings.Split(key, "_")
+	if len(parts) != 4 {
+		return fmt.Errorf("dotenv: %q must be TRANSFORM_<FROM>_<TO>_<FIELD>", key)
+	}
+	from, to, field := parts[1], parts[2], parts[3]
+
+	mapKey := canonicalTransformKey(from) + "->" + canonicalTransformKey(to)
+	tf, ok := conf.Transformations[mapKey]
+	if !ok {
+		tf = &Transformation{}
+		conf.Transformations[mapKey] = tf
+	}
+
+	switch field {
+	case "PRIORITY":
+		n, err := strconv.Atoi(val)
+		if err != nil {
+			return fmt.Errorf("dotenv: %s: %w", key, err)
+		}
+		tf.Priority = n
+	case "CONFIDENCE":
+		n, err := strconv.Atoi(val)
+		if err != nil {
+			return fmt.Errorf("dotenv: %s: %w", key, err)
+		}
+		tf.Confidence = n
+	case "EXCLUDE":
+		tf.Exclude = nil
+		for _, e := range strings.Split(val, ",") {
+			if e = strings.TrimSpace(e); e != "" {
+				tf.Exclude = append(tf.Exclude, e)
+			}
+		}
+	default:
+		return fmt.Errorf("dotenv: unrecognized transformation field %q in %q", field, key)
+	}
+	return nil
+}
+
+// canonicalTransformKey recovers the mixed-case spelling the YAML/JSON/TOML
+// decoders would produce for an upper-cased dotenv key segment (e.g.
+// "IPADDRESS" -> "IPAddress"), so the resulting map key is the same
+// regardless of source format. The "none" and "ALL" sentinels aren't OAM
+// asset types; they're matched case-insensitively and written as "none"
+// and "ALL", the spellings the other formats use (loadTransformSettings
+// lower-cases them either way). Any other segment is returned unchanged.
+func canonicalTransformKey(segment string) string {
+	if name, ok := canonicalAssetTypeName(segment); ok {
+		return name
+	}
+	switch strings.ToUpper(segment) {
+	case "NONE":
+		return "none"
+	case "ALL":
+		return "ALL"
+	default:
+		return segment
+	}
+}
@@ -0,0 +1,130 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadConfigFormats(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct {
+		name     string
+		filename string
+		content  string
+	}{
+		{
+			name:     "yaml",
+			filename: "config.yaml",
+			content: `
+options:
+  confidence: 50
+transformations:
+  FQDN->IPAddress:
+    priority: 1
+    confidence: 80
+`,
+		},
+		{
+			name:     "json",
+			filename: "config.json",
+			content: `{
+  "options": {"confidence": 50},
+  "transformations": {
+    "FQDN->IPAddress": {"priority": 1, "confidence": 80}
+  }
+}`,
+		},
+		{
+			name:     "toml",
+			filename: "config.toml",
+			content: `
+[options]
+confidence = 50
+
+[transformations."FQDN->IPAddress"]
+priority = 1
+confidence = 80
+`,
+		},
+		{
+			name:     "dotenv",
+			filename: "config.env",
+			content: `
+OPTIONS_CONFIDENCE=50
+TRANSFORM_FQDN_IPADDRESS_PRIORITY=1
+TRANSFORM_FQDN_IPADDRESS_CONFIDENCE=80
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, tt.filename)
+			if err := os.WriteFile(path, []byte(tt.content), 0o644); err != nil {
+				t.Fatalf("writing config: %v", err)
+			}
+
+			conf, err := LoadConfig(path)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			tf := conf.Transformations["FQDN->IPAddress"]
+			if tf == nil {
+				t.Fatalf("expected FQDN->IPAddress to be decoded")
+			}
+			if tf.From != "fqdn" || tf.To != "ipaddress" {
+				t.Errorf("expected From/To to be split and lower-cased, got %q/%q", tf.From, tf.To)
+			}
+			if tf.Priority != 1 || tf.Confidence != 80 {
+				t.Errorf("expected priority 1 confidence 80, got %d/%d", tf.Priority, tf.Confidence)
+			}
+		})
+	}
+}
+
+func TestWithFormatOverridesExtension(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.conf")
+	content := `{"options": {"confidence": 50}, "transformations": {"FQDN->IPAddress": {"priority": 1, "confidence": 80}}}`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("writing config: %v", err)
+	}
+
+	if _, err := LoadConfig(path); err == nil {
+		t.Fatalf("expected an unrecognized extension to error without WithFormat")
+	}
+
+	conf, err := LoadConfig(path, WithFormat(".json"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if conf.Transformations["FQDN->IPAddress"].Confidence != 80 {
+		t.Errorf("expected WithFormat(\".json\") to decode as JSON")
+	}
+}
+
+func TestRegisterDecoder(t *testing.T) {
+	RegisterDecoder(".csv", DecoderFunc(func(data []byte, conf *Config) error {
+		conf.BaseOptions.Confidence = 42
+		conf.Transformations = map[string]*Transformation{
+			"FQDN->IPAddress": {Priority: 1, Confidence: 80},
+		}
+		return nil
+	}))
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.csv")
+	if err := os.WriteFile(path, []byte("ignored"), 0o644); err != nil {
+		t.Fatalf("writing config: %v", err)
+	}
+
+	conf, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if conf.BaseOptions.Confidence != 42 {
+		t.Errorf("expected custom decoder to run, got confidence %d", conf.BaseOptions.Confidence)
+	}
+}
@@ -0,0 +1,115 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// envPattern matches ${NAME}, ${NAME:-default}, and ${NAME:?err message}.
+var envPattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)(:-|:\?)?([^}]*)\}`)
+
+// expandEnv substitutes ${VAR}, ${VAR:-default}, and ${VAR:?err msg}
+// references in data with values from the environment. A plain ${VAR}
+// whose variable is unset expands to the empty string; ${VAR:?err msg}
+// instead returns a descriptive error naming the variable and the given
+// message (or a generic one if none was given).
+func expandEnv(data []byte) ([]byte, error) {
+	var firstErr error
+
+	out := envPattern.ReplaceAllFunc(data, func(match []byte) []byte {
+		if firstErr != nil {
+			return match
+		}
+
+		sub := envPattern.FindSubmatch(match)
+		name, op, rest := string(sub[1]), string(sub[2]), string(sub[3])
+
+		if val, ok := os.LookupEnv(name); ok {
+			return []byte(val)
+		}
+
+		switch op {
+		case ":-":
+			return []byte(rest)
+		case ":?":
+			msg := rest
+			if msg == "" {
+				msg = "required environment variable is not set"
+			}
+			firstErr = fmt.Errorf("%s: %s", name, msg)
+			return nil
+		default:
+			return nil
+		}
+	})
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return out, nil
+}
+
+// transformEnvName derives the env-var infix for a "From->To" transformation
+// key, e.g. "FQDN->IPAddress" becomes "FQDN_IPADDRESS".
+func transformEnvName(key string) (string, bool) {
+	parts := strings.Split(key, "->")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", false
+	}
+	return strings.ToUpper(parts[0]) + "_" + strings.ToUpper(parts[1]), true
+}
+
+// applyEnvOverrides lets CONFIG_TRANSFORM_<FROM>_<TO>_{CONFIDENCE,PRIORITY,
+// EXCLUDE} and CONFIG_OPTIONS_CONFIDENCE override values decoded from the
+// config file, so deployments can parameterize confidence thresholds and
+// priorities without rewriting it.
+func (c *Config) applyEnvOverrides() error {
+	for key, tf := range c.Transformations {
+		name, ok := transformEnvName(key)
+		if !ok {
+			continue
+		}
+		if tf == nil {
+			// A key with no YAML body, e.g. "IPAddress->RIRORG:", decodes to
+			// a nil entry; loadTransformSettings normalizes this later, but
+			// overrides run first and need something to write into.
+			tf = &Transformation{}
+			c.Transformations[key] = tf
+		}
+		prefix := "CONFIG_TRANSFORM_" + name + "_"
+
+		if v, ok := os.LookupEnv(prefix + "CONFIDENCE"); ok {
+			n, err := strconv.Atoi(v)
+			if err != nil {
+				return fmt.Errorf("%sCONFIDENCE: %w", prefix, err)
+			}
+			tf.Confidence = n
+		}
+		if v, ok := os.LookupEnv(prefix + "PRIORITY"); ok {
+			n, err := strconv.Atoi(v)
+			if err != nil {
+				return fmt.Errorf("%sPRIORITY: %w", prefix, err)
+			}
+			tf.Priority = n
+		}
+		if v, ok := os.LookupEnv(prefix + "EXCLUDE"); ok {
+			tf.Exclude = nil
+			for _, e := range strings.Split(v, ",") {
+				if e = strings.TrimSpace(e); e != "" {
+					tf.Exclude = append(tf.Exclude, e)
+				}
+			}
+		}
+	}
+
+	if v, ok := os.LookupEnv("CONFIG_OPTIONS_CONFIDENCE"); ok {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("CONFIG_OPTIONS_CONFIDENCE: %w", err)
+		}
+		c.BaseOptions.Confidence = n
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+package config
+
+import (
+	"os"
+	"testing"
+)
+
+func TestExpandEnv(t *testing.T) {
+	t.Setenv("CONFIG_TEST_VAR", "80")
+
+	tests := []struct {
+		name      string
+		input     string
+		want      string
+		expectErr bool
+	}{
+		{
+			name:  "set variable is substituted",
+			input: "confidence: ${CONFIG_TEST_VAR}",
+			want:  "confidence: 80",
+		},
+		{
+			name:  "unset variable with default",
+			input: "confidence: ${CONFIG_TEST_MISSING:-50}",
+			want:  "confidence: 50",
+		},
+		{
+			name:  "unset variable with no default expands to empty",
+			input: "confidence: ${CONFIG_TEST_MISSING}",
+			want:  "confidence: ",
+		},
+		{
+			name:      "unset required variable errors",
+			input:     "confidence: ${CONFIG_TEST_MISSING:?must be set}",
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := expandEnv([]byte(tt.input))
+			if tt.expectErr {
+				if err == nil {
+					t.Fatalf("expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(out) != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, out)
+			}
+		})
+	}
+}
+
+func TestApplyEnvOverrides(t *testing.T) {
+	t.Run("transform-specific overrides", func(t *testing.T) {
+		t.Setenv("CONFIG_TRANSFORM_FQDN_IPADDRESS_CONFIDENCE", "95")
+		t.Setenv("CONFIG_TRANSFORM_FQDN_IPADDRESS_PRIORITY", "3")
+		t.Setenv("CONFIG_TRANSFORM_FQDN_ALL_EXCLUDE", "tls, fqdn")
+
+		conf, err := prepareConfig(validYAML)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := conf.applyEnvOverrides(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		tf := conf.Transformations["FQDN->IPAddress"]
+		if tf.Confidence != 95 {
+			t.Errorf("expected confidence 95, got %d", tf.Confidence)
+		}
+		if tf.Priority != 3 {
+			t.Errorf("expected priority 3, got %d", tf.Priority)
+		}
+
+		all := conf.Transformations["FQDN->ALL"]
+		if len(all.Exclude) != 2 || all.Exclude[0] != "tls" || all.Exclude[1] != "fqdn" {
+			t.Errorf("expected exclude [tls fqdn], got %v", all.Exclude)
+		}
+	})
+
+	t.Run("global confidence override", func(t *testing.T) {
+		t.Setenv("CONFIG_OPTIONS_CONFIDENCE", "33")
+
+		conf, err := prepareConfig(validYAML)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := conf.applyEnvOverrides(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if conf.BaseOptions.Confidence != 33 {
+			t.Errorf("expected global confidence 33, got %d", conf.BaseOptions.Confidence)
+		}
+	})
+
+	t.Run("override on an empty-bodied transformation", func(t *testing.T) {
+		t.Setenv("CONFIG_TRANSFORM_IPADDRESS_RIRORG_CONFIDENCE", "95")
+
+		conf, err := prepareConfig(validYAML)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := conf.applyEnvOverrides(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		tf := conf.Transformations["IPAddress->RIRORG"]
+		if tf == nil {
+			t.Fatalf("expected IPAddress->RIRORG to be present")
+		}
+		if tf.Confidence != 95 {
+			t.Errorf("expected confidence 95, got %d", tf.Confidence)
+		}
+	})
+
+	t.Run("invalid override value errors", func(t *testing.T) {
+		t.Setenv("CONFIG_TRANSFORM_FQDN_IPADDRESS_CONFIDENCE", "not-a-number")
+
+		conf, err := prepareConfig(validYAML)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := conf.applyEnvOverrides(); err == nil {
+			t.Fatalf("expected error for non-numeric override, got nil")
+		}
+	})
+}
+
+func TestLoadConfigEnvPrecedenceOverOverlay(t *testing.T) {
+	dir := t.TempDir()
+	base := dir + "/config.yaml"
+	if err := os.WriteFile(base, validYAML, 0o644); err != nil {
+		t.Fatalf("writing base config: %v", err)
+	}
+	overlay := []byte("transformations:\n  FQDN->IPAddress:\n    confidence: 60\n")
+	if err := os.WriteFile(base+".local", overlay, 0o644); err != nil {
+		t.Fatalf("writing overlay: %v", err)
+	}
+
+	t.Setenv("CONFIG_TRANSFORM_FQDN_IPADDRESS_CONFIDENCE", "99")
+
+	conf, err := LoadConfig(base)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := conf.Transformations["FQDN->IPAddress"].Confidence; got != 99 {
+		t.Errorf("expected env override (99) to win over overlay (60), got %d", got)
+	}
+}
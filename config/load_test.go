@@ -0,0 +1,75 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadConfigWithLocalOverlay(t *testing.T) {
+	dir := t.TempDir()
+	base := filepath.Join(dir, "config.yaml")
+
+	if err := os.WriteFile(base, validYAML, 0o644); err != nil {
+		t.Fatalf("writing base config: %v", err)
+	}
+
+	t.Run("no overlay present", func(t *testing.T) {
+		conf, err := LoadConfig(base)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if conf.Transformations["FQDN->IPAddress"].Confidence != 80 {
+			t.Errorf("expected base confidence to be preserved")
+		}
+	})
+
+	t.Run("overlay bumps confidence without touching priority", func(t *testing.T) {
+		overlay := []byte(`
+transformations:
+  FQDN->IPAddress:
+    confidence: 95
+`)
+		if err := os.WriteFile(base+".local", overlay, 0o644); err != nil {
+			t.Fatalf("writing overlay: %v", err)
+		}
+
+		conf, err := LoadConfig(base)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		tf := conf.Transformations["FQDN->IPAddress"]
+		if tf.Confidence != 95 {
+			t.Errorf("expected overlay confidence 95, got %d", tf.Confidence)
+		}
+		if tf.Priority != 1 {
+			t.Errorf("expected base priority to be left alone, got %d", tf.Priority)
+		}
+	})
+
+	t.Run("overlay exclude appends with + suffix", func(t *testing.T) {
+		overlay := []byte(`
+transformations:
+  FQDN->ALL:
+    exclude+: [TLS]
+`)
+		if err := os.WriteFile(base+".local", overlay, 0o644); err != nil {
+			t.Fatalf("writing overlay: %v", err)
+		}
+
+		conf, err := LoadConfig(base)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		exclude := conf.Transformations["FQDN->ALL"].Exclude
+		want := map[string]bool{"rirorg": false, "fqdn": false, "tls": false}
+		for _, e := range exclude {
+			want[e] = true
+		}
+		for k, found := range want {
+			if !found {
+				t.Errorf("expected exclude list to contain %q, got %v", k, exclude)
+			}
+		}
+	})
+}
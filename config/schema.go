@@ -0,0 +1,91 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Schema returns a JSON Schema (draft 2020-12) document describing the
+// Config format: the options block, the transformations map keyed by
+// "From->To" (validated against the known OAM asset types plus the "none"
+// and "ALL" sentinels), and each transformation's priority/confidence/
+// exclude fields. Property names are taken from the Options and
+// Transformation "yaml" struct tags and property types from the fields' Go
+// kinds, so the schema can't drift from the Go types it describes.
+func Schema() map[string]interface{} {
+	return map[string]interface{}{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"$id":     "https://github.com/cupello/config/schema",
+		"title":   "Config",
+		"type":    "object",
+		"properties": map[string]interface{}{
+			"options":         structSchema(reflect.TypeOf(Options{})),
+			"transformations": transformationsSchema(),
+		},
+		"additionalProperties": false,
+	}
+}
+
+// transformationsSchema describes the "transformations" map: its keys must
+// match "From->To" (or "From->none"/"From->ALL"), and each value is either
+// validated against the Transformation struct or, for a key with no body
+// (e.g. "IPAddress->RIRORG:" with nothing under it, which falls back to
+// the global default confidence), null.
+func transformationsSchema() map[string]interface{} {
+	assetPattern := "(?i)(" + strings.Join(oamAssetTypeNames, "|") + ")"
+
+	return map[string]interface{}{
+		"type": "object",
+		"propertyNames": map[string]interface{}{
+			"pattern": fmt.Sprintf(`^%s->(%s|none|ALL)$`, assetPattern, assetPattern),
+		},
+		"additionalProperties": map[string]interface{}{
+			"anyOf": []interface{}{
+				structSchema(reflect.TypeOf(Transformation{})),
+				map[string]interface{}{"type": "null"},
+			},
+		},
+	}
+}
+
+// structSchema builds a JSON Schema object describing t's exported fields,
+// using each field's "yaml" tag as the property name (fields tagged "-" are
+// skipped, since those - like Transformation.From/To - aren't part of the
+// YAML value body).
+func structSchema(t reflect.Type) map[string]interface{} {
+	props := map[string]interface{}{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("yaml"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		props[name] = fieldSchema(f)
+	}
+	return map[string]interface{}{
+		"type":       "object",
+		"properties": props,
+	}
+}
+
+// fieldSchema infers a JSON Schema type for a single struct field from its
+// Go kind.
+func fieldSchema(f reflect.StructField) map[string]interface{} {
+	switch f.Type.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		s := map[string]interface{}{"type": "integer"}
+		if strings.EqualFold(f.Name, "Confidence") {
+			s["minimum"] = 0
+			s["maximum"] = 100
+		}
+		return s
+	case reflect.Slice:
+		return map[string]interface{}{
+			"type":  "array",
+			"items": map[string]interface{}{"type": "string"},
+		}
+	default:
+		return map[string]interface{}{"type": "string"}
+	}
+}
@@ -0,0 +1,54 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSchemaDescribesKnownFields(t *testing.T) {
+	raw, err := json.Marshal(Schema())
+	if err != nil {
+		t.Fatalf("schema must marshal to JSON: %v", err)
+	}
+	s := string(raw)
+
+	for _, want := range []string{"confidence", "priority", "exclude", "transformations", "options"} {
+		if !strings.Contains(s, want) {
+			t.Errorf("expected schema to mention %q, got:\n%s", want, s)
+		}
+	}
+}
+
+func TestLoadConfigWithSchemaValidation(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+
+	t.Run("valid config passes", func(t *testing.T) {
+		if err := os.WriteFile(path, validYAML, 0o644); err != nil {
+			t.Fatalf("writing config: %v", err)
+		}
+		if _, err := LoadConfig(path, WithSchemaValidation()); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("out of range confidence fails schema validation", func(t *testing.T) {
+		bad := []byte(`
+options:
+  confidence: 50
+transformations:
+  FQDN->IPAddress:
+    priority: 1
+    confidence: 150
+`)
+		if err := os.WriteFile(path, bad, 0o644); err != nil {
+			t.Fatalf("writing config: %v", err)
+		}
+		if _, err := LoadConfig(path, WithSchemaValidation()); err == nil {
+			t.Errorf("expected schema validation to reject confidence 150")
+		}
+	})
+}
@@ -0,0 +1,58 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+	"gopkg.in/yaml.v3"
+)
+
+// WithSchemaValidation makes LoadConfig validate the decoded document
+// against Schema() before running the existing Go-level checks
+// (loadTransformSettings' none/ALL conflict detection and OAM compliance).
+// Schema errors wrap the jsonschema validation error, which cites the
+// offending JSON pointer, e.g. "/transformations/FQDN->IPAddress/confidence"
+// for a confidence above the schema's maximum of 100. Only the
+// YAML and JSON formats are validated this way; other formats are not
+// representable as a single JSON document and are skipped.
+func WithSchemaValidation() LoadOption {
+	return func(o *loadOptions) { o.validateSchema = true }
+}
+
+// validateAgainstSchema parses data as a generic document and validates it
+// against Schema(). format selects the parser; non-YAML/JSON formats are a
+// no-op.
+func validateAgainstSchema(data []byte, format string) error {
+	if format != ".yaml" && format != ".yml" && format != ".json" {
+		return nil
+	}
+
+	var doc interface{}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("schema validation: parsing document: %w", err)
+	}
+
+	schema, err := compileSchema()
+	if err != nil {
+		return fmt.Errorf("schema validation: compiling schema: %w", err)
+	}
+	if err := schema.Validate(doc); err != nil {
+		return fmt.Errorf("schema validation: %w", err)
+	}
+	return nil
+}
+
+func compileSchema() (*jsonschema.Schema, error) {
+	raw, err := json.Marshal(Schema())
+	if err != nil {
+		return nil, err
+	}
+
+	compiler := jsonschema.NewCompiler()
+	if err := compiler.AddResource("config.schema.json", bytes.NewReader(raw)); err != nil {
+		return nil, err
+	}
+	return compiler.Compile("config.schema.json")
+}
@@ -0,0 +1,333 @@
+// Package config loads and validates the transformation configuration that
+// drives which OAM asset types Amass is permitted to pivot between during a
+// session, along with the priority and confidence each transformation is
+// assigned.
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/cupello/config/yamlpatch"
+)
+
+// Options holds the session-wide defaults that apply unless a specific
+// transformation overrides them.
+type Options struct {
+	Confidence int `yaml:"confidence" json:"confidence" toml:"confidence"`
+}
+
+// Transformation describes the priority, confidence and exclusions for
+// pivoting from one OAM asset type to another. From and To are derived from
+// the "From->To" map key via Split and are always lower-cased.
+type Transformation struct {
+	From       string   `yaml:"-" json:"-" toml:"-"`
+	To         string   `yaml:"-" json:"-" toml:"-"`
+	Priority   int      `yaml:"priority" json:"priority" toml:"priority"`
+	Confidence int      `yaml:"confidence" json:"confidence" toml:"confidence"`
+	Exclude    []string `yaml:"exclude" json:"exclude" toml:"exclude"`
+}
+
+// Config is the top-level transformation configuration document.
+type Config struct {
+	// BaseOptions is the Options decoded from the config file. Once Watch
+	// has been started, use the Options method rather than this field
+	// directly: it is not updated on reload, and reading it concurrently
+	// with a Watch-driven reload is a data race.
+	BaseOptions     Options                    `yaml:"options" json:"options" toml:"options"`
+	Transformations map[string]*Transformation `yaml:"transformations"`
+
+	path string
+
+	// live holds the Watch-swapped Options and Transformations together, once
+	// a Watch-driven reload has succeeded; it takes precedence over BaseOptions/
+	// Transformations so concurrent readers never observe one updated and
+	// the other still stale.
+	live atomic.Pointer[liveConfig]
+
+	subMu       sync.Mutex
+	subscribers []func(old, new *Config)
+}
+
+// liveConfig is the unit Watch swaps atomically: a reload always replaces
+// both fields together, never one at a time.
+type liveConfig struct {
+	options         Options
+	transformations map[string]*Transformation
+}
+
+// Options returns the currently active Options: the Watch-swapped value if
+// Watch has successfully reloaded at least once, otherwise the value
+// decoded by LoadConfig. Unlike the BaseOptions field, it is safe to call
+// concurrently with a running Watch.
+func (c *Config) Options() Options {
+	if p := c.live.Load(); p != nil {
+		return p.options
+	}
+	return c.BaseOptions
+}
+
+// currentTransformations returns the Watch-swapped transformation set if
+// Watch has successfully reloaded at least once, otherwise the statically
+// configured one.
+func (c *Config) currentTransformations() map[string]*Transformation {
+	if p := c.live.Load(); p != nil {
+		return p.transformations
+	}
+	return c.Transformations
+}
+
+// Matches is the set of "to" asset types a CheckTransformations call
+// resolved for a given "from" asset type.
+type Matches struct {
+	to map[string]struct{}
+}
+
+// oamAssetTypeNames are the open asset model types that may appear on
+// either side of a transformation key, in their canonical display casing.
+// It is the single source of truth for both key validation and the
+// generated JSON Schema (see Schema).
+var oamAssetTypeNames = []string{
+	"FQDN", "IPAddress", "DomainRecord", "Netblock", "RIRORG",
+	"AutonomousSystem", "TLS", "Registrant", "Contact", "Organization",
+	"Person", "Location", "URL", "Identifier", "Fingerprint",
+}
+
+// validOAMAssetTypes is oamAssetTypeNames lower-cased for O(1) lookup
+// against the already lower-cased Transformation.From/To fields.
+var validOAMAssetTypes = func() map[string]struct{} {
+	m := make(map[string]struct{}, len(oamAssetTypeNames))
+	for _, name := range oamAssetTypeNames {
+		m[strings.ToLower(name)] = struct{}{}
+	}
+	return m
+}()
+
+func isValidOAMAssetType(t string) bool {
+	_, ok := validOAMAssetTypes[t]
+	return ok
+}
+
+// canonicalAssetTypeNames maps a lower-cased OAM asset type to its
+// canonical display casing, e.g. "ipaddress" -> "IPAddress".
+var canonicalAssetTypeNames = func() map[string]string {
+	m := make(map[string]string, len(oamAssetTypeNames))
+	for _, name := range oamAssetTypeNames {
+		m[strings.ToLower(name)] = name
+	}
+	return m
+}()
+
+// canonicalAssetTypeName returns s in its canonical display casing if it
+// names a known OAM asset type, matched case-insensitively.
+func canonicalAssetTypeName(s string) (string, bool) {
+	name, ok := canonicalAssetTypeNames[strings.ToLower(s)]
+	return name, ok
+}
+
+// NewConfig returns an empty, ready-to-populate Config.
+func NewConfig() *Config {
+	return &Config{
+		Transformations: make(map[string]*Transformation),
+	}
+}
+
+// LoadOption configures a LoadConfig call.
+type LoadOption func(*loadOptions)
+
+type loadOptions struct {
+	format         string
+	validateSchema bool
+}
+
+// WithFormat forces LoadConfig to decode path with the decoder registered
+// for format (e.g. ".json"), overriding the format it would otherwise infer
+// from path's extension.
+func WithFormat(format string) LoadOption {
+	return func(o *loadOptions) { o.format = format }
+}
+
+// LoadConfig reads the transformation configuration at path, decoding it
+// with the ConfigDecoder registered for its extension (or the format given
+// via WithFormat). For YAML files, a sibling "<path>.local" overlay file is
+// deep-merged over the base document (see the yamlpatch package) before
+// decoding, allowing operators to pin per-host tweaks without touching the
+// base config shipped via configuration management. ${VAR} references are
+// then expanded and, after decoding, CONFIG_TRANSFORM_*/
+// CONFIG_OPTIONS_CONFIDENCE environment variables are applied. Precedence,
+// highest first: environment overrides, the local overlay, the base file.
+func LoadConfig(path string, opts ...LoadOption) (*Config, error) {
+	var lo loadOptions
+	for _, opt := range opts {
+		opt(&lo)
+	}
+	format := lo.format
+	if format == "" {
+		format = filepath.Ext(path)
+	}
+	decoder, ok := decoderFor(format)
+	if !ok {
+		return nil, fmt.Errorf("config: no decoder registered for format %q", format)
+	}
+
+	base, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading config %s: %w", path, err)
+	}
+
+	merged := base
+	if format == ".yaml" || format == ".yml" {
+		if overlay, oerr := os.ReadFile(path + ".local"); oerr == nil {
+			if merged, err = yamlpatch.Merge(base, overlay); err != nil {
+				return nil, fmt.Errorf("merging %s.local overlay: %w", path, err)
+			}
+		} else if !os.IsNotExist(oerr) {
+			return nil, fmt.Errorf("reading %s.local overlay: %w", path, oerr)
+		}
+	}
+
+	merged, err = expandEnv(merged)
+	if err != nil {
+		return nil, fmt.Errorf("expanding config %s: %w", path, err)
+	}
+
+	if lo.validateSchema {
+		if err := validateAgainstSchema(merged, format); err != nil {
+			return nil, fmt.Errorf("validating config %s: %w", path, err)
+		}
+	}
+
+	conf := NewConfig()
+	if err := decoder.Decode(merged, conf); err != nil {
+		return nil, fmt.Errorf("parsing config %s: %w", path, err)
+	}
+	conf.path = path
+
+	if err := conf.applyEnvOverrides(); err != nil {
+		return nil, fmt.Errorf("applying environment overrides for %s: %w", path, err)
+	}
+
+	if err := conf.loadTransformSettings(conf); err != nil {
+		return nil, err
+	}
+	return conf, nil
+}
+
+// Split parses a "From->To" transformation key into its lower-cased From
+// and To fields.
+func (t *Transformation) Split(key string) error {
+	parts := strings.Split(key, "->")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return fmt.Errorf("%q is not a valid transformation key, expected From->To", key)
+	}
+	t.From = strings.ToLower(parts[0])
+	t.To = strings.ToLower(parts[1])
+	return nil
+}
+
+// IsMatch reports whether to is present in the Matches set.
+func (m *Matches) IsMatch(to string) bool {
+	if m == nil {
+		return false
+	}
+	_, ok := m.to[to]
+	return ok
+}
+
+// loadTransformSettings splits and validates every transformation key in
+// conf, applies the global default confidence where one was not specified,
+// and stores the result on c. It rejects malformed keys, non-OAM asset
+// types, and any "From->none" entry that conflicts with another
+// transformation defined for the same From.
+func (c *Config) loadTransformSettings(conf *Config) error {
+	noneFrom := make(map[string]struct{})
+	seenFrom := make(map[string]int)
+
+	for key, tf := range conf.Transformations {
+		if tf == nil {
+			// A key with no body, e.g. "IPAddress->RIRORG:", unmarshals to a
+			// nil entry; treat it as an empty Transformation that takes the
+			// global default confidence below.
+			tf = &Transformation{}
+			conf.Transformations[key] = tf
+		}
+		if err := tf.Split(key); err != nil {
+			return err
+		}
+		if !isValidOAMAssetType(tf.From) {
+			return fmt.Errorf("%q in %q is not a valid OAM asset type", tf.From, key)
+		}
+		if tf.To != "none" && tf.To != "all" && !isValidOAMAssetType(tf.To) {
+			return fmt.Errorf("%q in %q is not a valid OAM asset type", tf.To, key)
+		}
+		if tf.To == "none" {
+			noneFrom[tf.From] = struct{}{}
+		}
+		seenFrom[tf.From]++
+
+		if tf.Confidence == 0 {
+			tf.Confidence = conf.BaseOptions.Confidence
+		}
+		for i, e := range tf.Exclude {
+			tf.Exclude[i] = strings.ToLower(e)
+		}
+	}
+
+	for from := range noneFrom {
+		if seenFrom[from] > 1 {
+			return fmt.Errorf("%q->none conflicts with other transformations defined for %q", from, from)
+		}
+	}
+
+	c.BaseOptions = conf.BaseOptions
+	c.Transformations = conf.Transformations
+	return nil
+}
+
+// CheckTransformations resolves which of the given "to" asset types are
+// reachable from the "from" asset type per the loaded configuration,
+// honoring any catch-all "From->ALL" entry and its exclusions. It returns
+// an error when none of the requested types match.
+func (c *Config) CheckTransformations(from string, tos ...string) (*Matches, error) {
+	m := &Matches{to: make(map[string]struct{})}
+
+	explicit := make(map[string]struct{})
+	var exclude map[string]struct{}
+	hasAll := false
+
+	for _, tf := range c.currentTransformations() {
+		if tf.From != from {
+			continue
+		}
+		if tf.To == "all" {
+			hasAll = true
+			exclude = make(map[string]struct{}, len(tf.Exclude))
+			for _, e := range tf.Exclude {
+				exclude[e] = struct{}{}
+			}
+			continue
+		}
+		explicit[tf.To] = struct{}{}
+	}
+
+	for _, to := range tos {
+		if _, ok := explicit[to]; ok {
+			m.to[to] = struct{}{}
+			continue
+		}
+		if hasAll {
+			if _, excluded := exclude[to]; !excluded {
+				m.to[to] = struct{}{}
+			}
+		}
+	}
+
+	if len(m.to) == 0 {
+		return m, fmt.Errorf("zero transformation matches in the session config")
+	}
+	return m, nil
+}
@@ -0,0 +1,175 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// EventType identifies what happened during a Watch-driven reload.
+type EventType int
+
+const (
+	// EventReloaded indicates the watched config was re-read and the new
+	// transformation set is now live.
+	EventReloaded EventType = iota
+	// EventError indicates a reload attempt failed validation or could not
+	// be read; the previously loaded config is still live.
+	EventError
+)
+
+// ConfigEvent reports the outcome of a single reload attempt triggered by
+// Watch.
+type ConfigEvent struct {
+	Type EventType
+	Err  error
+}
+
+// watchDebounce coalesces the bursts of write events that editors such as
+// vim and helm tend to emit for a single logical save.
+const watchDebounce = 250 * time.Millisecond
+
+// Watch starts watching the file this Config was loaded from (and its
+// ".local" overlay, if present) for changes. On each change it re-reads and
+// re-validates the config; if validation succeeds, it atomically swaps the
+// live transformation set so concurrent CheckTransformations callers never
+// observe a half-loaded config, and emits an EventReloaded. On failure the
+// previous config stays live and an EventError is emitted instead. Watch
+// re-adds its watch when the file's inode changes, handling the
+// rename-then-write pattern vim and helm use for saves. Each reload calls
+// LoadConfig with the path alone: LoadOptions given to the original
+// LoadConfig call, such as WithFormat or WithSchemaValidation, are not
+// applied again. The returned channel is closed once ctx is done.
+func (c *Config) Watch(ctx context.Context) (<-chan ConfigEvent, error) {
+	if c.path == "" {
+		return nil, errors.New("config: Watch requires a Config loaded via LoadConfig")
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("config: starting watcher: %w", err)
+	}
+	if err := c.addWatches(watcher); err != nil {
+		watcher.Close()
+		return nil, err
+	}
+
+	events := make(chan ConfigEvent, 1)
+	go c.watchLoop(ctx, watcher, events)
+	return events, nil
+}
+
+// Subscribe registers fn to be called with the previous and newly live
+// Config every time Watch swaps in a successfully validated reload.
+func (c *Config) Subscribe(fn func(old, new *Config)) {
+	c.subMu.Lock()
+	defer c.subMu.Unlock()
+	c.subscribers = append(c.subscribers, fn)
+}
+
+func (c *Config) addWatches(watcher *fsnotify.Watcher) error {
+	if err := watcher.Add(c.path); err != nil {
+		return fmt.Errorf("config: watching %s: %w", c.path, err)
+	}
+	if _, err := os.Stat(c.path + ".local"); err == nil {
+		if err := watcher.Add(c.path + ".local"); err != nil {
+			return fmt.Errorf("config: watching %s.local: %w", c.path, err)
+		}
+	}
+	return nil
+}
+
+func (c *Config) watchLoop(ctx context.Context, watcher *fsnotify.Watcher, events chan<- ConfigEvent) {
+	defer watcher.Close()
+	defer close(events)
+
+	var debounce *time.Timer
+	pending := make(chan struct{}, 1)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case ev, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+			if ev.Op&(fsnotify.Remove|fsnotify.Rename) != 0 {
+				go c.rewatchAfterRename(ctx, watcher, ev.Name)
+			}
+			if debounce == nil {
+				debounce = time.AfterFunc(watchDebounce, func() {
+					select {
+					case pending <- struct{}{}:
+					default:
+					}
+				})
+			} else {
+				debounce.Reset(watchDebounce)
+			}
+
+		case <-pending:
+			debounce = nil
+			c.reload(events)
+
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			events <- ConfigEvent{Type: EventError, Err: err}
+		}
+	}
+}
+
+// rewatchAfterRename re-adds the watch on name once it reappears, for
+// editors that save by renaming a temp file over the watched path (which
+// leaves fsnotify watching a now-deleted inode).
+func (c *Config) rewatchAfterRename(ctx context.Context, watcher *fsnotify.Watcher, name string) {
+	for i := 0; i < 10; i++ {
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(50 * time.Millisecond):
+		}
+		if _, err := os.Stat(name); err == nil {
+			_ = watcher.Add(name)
+			return
+		}
+	}
+}
+
+func (c *Config) reload(events chan<- ConfigEvent) {
+	next, err := LoadConfig(c.path)
+	if err != nil {
+		events <- ConfigEvent{Type: EventError, Err: err}
+		return
+	}
+
+	prev := c.snapshot()
+	c.live.Store(&liveConfig{options: next.BaseOptions, transformations: next.Transformations})
+
+	c.subMu.Lock()
+	subs := append([]func(old, new *Config){}, c.subscribers...)
+	c.subMu.Unlock()
+	for _, fn := range subs {
+		fn(prev, next)
+	}
+
+	events <- ConfigEvent{Type: EventReloaded}
+}
+
+// snapshot captures c's currently live Options and Transformations in a
+// fresh Config, suitable for handing to a Subscribe callback as the "old"
+// config after a reload.
+func (c *Config) snapshot() *Config {
+	return &Config{
+		BaseOptions:     c.Options(),
+		Transformations: c.currentTransformations(),
+		path:            c.path,
+	}
+}
@@ -0,0 +1,69 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReloadSwapsLiveTransformationsAndNotifiesSubscribers(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	if err := os.WriteFile(path, validYAML, 0o644); err != nil {
+		t.Fatalf("writing config: %v", err)
+	}
+
+	conf, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var gotOld, gotNew *Config
+	conf.Subscribe(func(old, new *Config) {
+		gotOld, gotNew = old, new
+	})
+
+	if err := os.WriteFile(path, conflictingNoneYAML, 0o644); err != nil {
+		t.Fatalf("rewriting config: %v", err)
+	}
+
+	events := make(chan ConfigEvent, 1)
+	conf.reload(events)
+
+	ev := <-events
+	if ev.Type != EventError {
+		t.Fatalf("expected invalid config to produce EventError, got %v (err=%v)", ev.Type, ev.Err)
+	}
+	if gotOld != nil || gotNew != nil {
+		t.Fatalf("subscriber should not fire on a failed reload")
+	}
+	if conf.currentTransformations()["FQDN->DomainRecord"].Confidence != 50 {
+		t.Errorf("failed reload must leave the previous config live")
+	}
+
+	if err := os.WriteFile(path, []byte(`
+options:
+  confidence: 77
+transformations:
+  FQDN->IPAddress:
+    priority: 1
+    confidence: 90
+`), 0o644); err != nil {
+		t.Fatalf("rewriting config: %v", err)
+	}
+
+	conf.reload(events)
+	ev = <-events
+	if ev.Type != EventReloaded {
+		t.Fatalf("expected EventReloaded, got %v (err=%v)", ev.Type, ev.Err)
+	}
+	if gotNew == nil {
+		t.Fatalf("subscriber should fire on a successful reload")
+	}
+	if got := conf.currentTransformations()["FQDN->IPAddress"].Confidence; got != 90 {
+		t.Errorf("expected live config to reflect the reload, got confidence %d", got)
+	}
+	if got := conf.Options().Confidence; got != 77 {
+		t.Errorf("expected Options() to reflect the reload, got confidence %d", got)
+	}
+}
@@ -0,0 +1,101 @@
+// Package yamlpatch deep-merges a YAML overlay document onto a base YAML
+// document, keeping the base's key order (overlay-only keys follow it) and
+// the comments on the nodes it keeps. A base value that the overlay
+// replaces is dropped along with its comments. It backs the config
+// package's support for "*.local" override files.
+package yamlpatch
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Merge parses base and overlay as YAML and returns the bytes of their deep
+// merge: scalars in overlay replace the corresponding scalar in base,
+// mapping keys are merged key-by-key, and sequence/scalar values replace
+// the base value outright unless the overlay key is suffixed with "+", in
+// which case a sequence value is appended to the base sequence instead. A
+// nil or empty overlay returns base unchanged.
+func Merge(base, overlay []byte) ([]byte, error) {
+	if len(overlay) == 0 {
+		return base, nil
+	}
+
+	var baseDoc, overlayDoc yaml.Node
+	if err := yaml.Unmarshal(base, &baseDoc); err != nil {
+		return nil, fmt.Errorf("parsing base document: %w", err)
+	}
+	if err := yaml.Unmarshal(overlay, &overlayDoc); err != nil {
+		return nil, fmt.Errorf("parsing overlay document: %w", err)
+	}
+
+	if len(overlayDoc.Content) == 0 {
+		return base, nil
+	}
+	if len(baseDoc.Content) == 0 {
+		return yaml.Marshal(&overlayDoc)
+	}
+
+	merged := mergeNodes(baseDoc.Content[0], overlayDoc.Content[0])
+	out := yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{merged}}
+
+	return yaml.Marshal(&out)
+}
+
+// mergeNodes merges overlay onto base. Only mapping nodes are merged
+// key-by-key; any other node kind (or a kind mismatch) means overlay wins
+// outright.
+func mergeNodes(base, overlay *yaml.Node) *yaml.Node {
+	if base == nil {
+		return overlay
+	}
+	if base.Kind != yaml.MappingNode || overlay.Kind != yaml.MappingNode {
+		return overlay
+	}
+	return mergeMappings(base, overlay)
+}
+
+func mergeMappings(base, overlay *yaml.Node) *yaml.Node {
+	result := &yaml.Node{
+		Kind:    yaml.MappingNode,
+		Tag:     base.Tag,
+		Style:   base.Style,
+		Content: append([]*yaml.Node{}, base.Content...),
+	}
+
+	for i := 0; i+1 < len(overlay.Content); i += 2 {
+		key, val := overlay.Content[i], overlay.Content[i+1]
+
+		appendMode := strings.HasSuffix(key.Value, "+")
+		name := strings.TrimSuffix(key.Value, "+")
+
+		idx := findKey(result, name)
+		switch {
+		case idx < 0:
+			if appendMode {
+				key = &yaml.Node{Kind: yaml.ScalarNode, Tag: key.Tag, Value: name}
+			}
+			result.Content = append(result.Content, key, val)
+		case appendMode && val.Kind == yaml.SequenceNode && result.Content[idx+1].Kind == yaml.SequenceNode:
+			existing := result.Content[idx+1]
+			existing.Content = append(existing.Content, val.Content...)
+		default:
+			result.Content[idx+1] = mergeNodes(result.Content[idx+1], val)
+		}
+	}
+
+	return result
+}
+
+// findKey returns the index of name's key node within mapping.Content, or
+// -1 if mapping has no such key.
+func findKey(mapping *yaml.Node, name string) int {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == name {
+			return i
+		}
+	}
+	return -1
+}
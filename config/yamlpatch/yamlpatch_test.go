@@ -0,0 +1,117 @@
+package yamlpatch
+
+import (
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// excludeList decodes merged's "transformations.<key>.exclude" list, for
+// assertions that need to look at one field rather than the whole
+// document (map keys like "FQDN->ALL" themselves contain "FQDN", which
+// would make a naive substring check pass or fail for the wrong reason).
+func excludeList(t *testing.T, merged []byte, key string) []string {
+	t.Helper()
+
+	var doc struct {
+		Transformations map[string]struct {
+			Exclude []string `yaml:"exclude"`
+		} `yaml:"transformations"`
+	}
+	if err := yaml.Unmarshal(merged, &doc); err != nil {
+		t.Fatalf("decoding merged output: %v", err)
+	}
+	return doc.Transformations[key].Exclude
+}
+
+func TestMerge(t *testing.T) {
+	base := []byte(`
+options:
+  confidence: 50
+
+transformations:
+  FQDN->IPAddress:
+    priority: 1
+    confidence: 80
+  FQDN->ALL:
+    exclude: [RIRORG, FQDN]
+`)
+
+	tests := []struct {
+		name    string
+		overlay []byte
+		want    []string
+		notWant []string
+		check   func(t *testing.T, merged []byte)
+	}{
+		{
+			name:    "scalar replace",
+			overlay: []byte("options:\n  confidence: 95\n"),
+			want:    []string{"confidence: 95"},
+			notWant: []string{"confidence: 50"},
+		},
+		{
+			name: "nested map merge leaves sibling fields alone",
+			overlay: []byte(`
+transformations:
+  FQDN->IPAddress:
+    confidence: 95
+`),
+			want:    []string{"confidence: 95", "priority: 1"},
+			notWant: []string{"confidence: 80"},
+		},
+		{
+			name: "list replace by default",
+			overlay: []byte(`
+transformations:
+  FQDN->ALL:
+    exclude: [TLS]
+`),
+			check: func(t *testing.T, merged []byte) {
+				got := excludeList(t, merged, "FQDN->ALL")
+				want := []string{"TLS"}
+				if len(got) != len(want) || got[0] != want[0] {
+					t.Errorf("expected exclude to be replaced with %v, got %v", want, got)
+				}
+			},
+		},
+		{
+			name: "append mode keeps base entries",
+			overlay: []byte(`
+transformations:
+  FQDN->ALL:
+    exclude+: [TLS]
+`),
+			want: []string{"RIRORG", "FQDN", "TLS"},
+		},
+		{
+			name:    "empty overlay returns base untouched",
+			overlay: nil,
+			want:    []string{"confidence: 50", "priority: 1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := Merge(base, tt.overlay)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got := string(out)
+			for _, w := range tt.want {
+				if !strings.Contains(got, w) {
+					t.Errorf("expected merged output to contain %q, got:\n%s", w, got)
+				}
+			}
+			for _, nw := range tt.notWant {
+				if strings.Contains(got, nw) {
+					t.Errorf("expected merged output not to contain %q, got:\n%s", nw, got)
+				}
+			}
+			if tt.check != nil {
+				tt.check(t, out)
+			}
+		})
+	}
+}